@@ -5,29 +5,71 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+
 	"github.com/junovy-hosting/flux-enhanced-cli/pkg/events"
 	"github.com/junovy-hosting/flux-enhanced-cli/pkg/output"
 )
 
+// repeatedFlag collects every occurrence of a flag that's passed more than
+// once, e.g. `-kind kustomization -kind helmrelease`, for specifying several
+// resources without a -f file.
+type repeatedFlag []string
+
+func (r *repeatedFlag) String() string { return strings.Join(*r, ",") }
+func (r *repeatedFlag) Set(v string) error {
+	*r = append(*r, v)
+	return nil
+}
+
+// resourceFile mirrors the subset of a Flux object's identity this tool
+// needs, as listed in a -f resources.yaml file.
+type resourceFile struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
 func main() {
 	var (
-		kind      = flag.String("kind", "", "Resource kind (kustomization, helmrelease, source)")
-		name      = flag.String("name", "", "Resource name")
-		namespace = flag.String("namespace", "flux-system", "Namespace")
-		wait      = flag.Bool("wait", true, "Wait for reconciliation to complete")
-		timeout   = flag.Duration("timeout", 5*time.Minute, "Timeout for waiting")
+		kinds      repeatedFlag
+		names      repeatedFlag
+		namespaces repeatedFlag
 	)
+	flag.Var(&kinds, "kind", "Resource kind (kustomization, helmrelease, source, ocirepository, bucket, helmchart, alert, provider, receiver, imagerepository, imagepolicy, imageupdateautomation); repeat for multiple resources")
+	flag.Var(&names, "name", "Resource name; repeat for multiple resources")
+	flag.Var(&namespaces, "namespace", "Namespace (default flux-system); repeat for multiple resources")
+	file := flag.String("f", "", "Path to a YAML file listing resources to reconcile (kind/name/namespace)")
+	wait := flag.Bool("wait", true, "Wait for reconciliation to complete")
+	timeout := flag.Duration("timeout", 5*time.Minute, "Timeout for waiting")
+	outputMode := flag.String("output", "text", "Output mode: text, json, or ndjson")
+	inCluster := flag.Bool("in-cluster", false, "Use in-cluster credentials only, skipping the local kubeconfig fallback (for running as a sidecar/Job)")
+	leaderElect := flag.Bool("leader-election", false, "Acquire a lease before reconciling, so only one of several replicas acts")
+	leaseName := flag.String("lease-name", "flux-reconcile-cli", "Lease name used for -leader-election")
 	flag.Parse()
 
-	if *kind == "" || *name == "" {
-		fmt.Fprintf(os.Stderr, "Error: --kind and --name are required\n")
+	events.SetInCluster(*inCluster)
+
+	sink, err := newSink(*outputMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	output.SetSink(sink)
+
+	refs, err := resolveResources(*file, kinds, names, namespaces)
+	if err != nil {
+		fatal(1, err.Error())
+	}
+	if len(refs) == 0 {
+		fatal(1, "--kind and --name are required (or pass -f/repeated flags for multiple resources)")
+	}
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
@@ -41,11 +83,113 @@ func main() {
 		cancel()
 	}()
 
-	// Start event monitoring (only if we have a valid kind for monitoring)
+	run := func(ctx context.Context) int {
+		if len(refs) > 1 {
+			return runBatch(ctx, refs, *timeout)
+		}
+		return runSingle(ctx, refs[0], *wait, *timeout)
+	}
+
+	var code int
+	if *leaderElect {
+		code = runWithLeaderElection(ctx, *leaseName, refs[0].Namespace, run)
+	} else {
+		code = run(ctx)
+	}
+
+	output.Close()
+	os.Exit(code)
+}
+
+// newSink constructs the Sink for the requested -output mode.
+func newSink(mode string) (output.Sink, error) {
+	switch mode {
+	case "", "text":
+		return output.NewTextSink(os.Stdout), nil
+	case "json":
+		return output.NewJSONSink(os.Stdout, false), nil
+	case "ndjson":
+		return output.NewJSONSink(os.Stdout, true), nil
+	default:
+		return nil, fmt.Errorf("unknown -output mode %q (want text, json, or ndjson)", mode)
+	}
+}
+
+// fatal reports message through the active sink, flushes it, and exits with
+// code. Sink.Close must run before exit so the buffered `json` mode still
+// emits its array.
+func fatal(code int, message string) {
+	output.PrintError(message)
+	output.Close()
+	os.Exit(code)
+}
+
+// resolveResources merges a -f resources.yaml file with repeated
+// -kind/-name/-namespace flags into a single resource list. Namespace
+// defaults to flux-system per entry when left unspecified.
+func resolveResources(file string, kinds, names, namespaces repeatedFlag) ([]events.ResourceRef, error) {
+	var refs []events.ResourceRef
+
+	if file != "" {
+		fileRefs, err := loadResourceFile(file)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, fileRefs...)
+	}
+
+	if len(kinds) > 0 {
+		if len(names) != len(kinds) {
+			return nil, fmt.Errorf("-kind and -name must be repeated the same number of times")
+		}
+		for i, kind := range kinds {
+			namespace := "flux-system"
+			if i < len(namespaces) {
+				namespace = namespaces[i]
+			}
+			refs = append(refs, events.ResourceRef{Kind: kind, Name: names[i], Namespace: namespace})
+		}
+	}
+
+	return refs, nil
+}
+
+func loadResourceFile(path string) ([]events.ResourceRef, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var specs []resourceFile
+	if err := yaml.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	refs := make([]events.ResourceRef, 0, len(specs))
+	for _, s := range specs {
+		namespace := s.Namespace
+		if namespace == "" {
+			namespace = "flux-system"
+		}
+		refs = append(refs, events.ResourceRef{Kind: s.Kind, Name: s.Name, Namespace: namespace})
+	}
+	return refs, nil
+}
+
+// runSingle triggers reconciliation of a single resource, then optionally
+// waits for readiness. It returns the process exit code rather than calling
+// os.Exit directly, so the caller can flush the output sink first.
+func runSingle(ctx context.Context, ref events.ResourceRef, wait bool, timeout time.Duration) int {
+	dynamicClient, err := events.NewDynamicClient()
+	if err != nil {
+		output.PrintError(err.Error())
+		return 1
+	}
+
 	var eventMonitor *events.Monitor
-	if *kind == "kustomization" || *kind == "helmrelease" || *kind == "source" {
+	if events.IsMonitorableKind(ref.Kind) {
 		var err error
-		eventMonitor, err = events.NewMonitor(ctx, *kind, *name, *namespace)
+		eventMonitor, err = events.NewMonitor(ctx, ref.Kind, ref.Name, ref.Namespace)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Could not start event monitoring: %v\n", err)
 		} else {
@@ -54,38 +198,86 @@ func main() {
 		}
 	}
 
-	// Build flux command
-	var cmd *exec.Cmd
-	if *kind == "source" {
-		// For source, we need "flux reconcile source git <name>"
-		cmd = exec.CommandContext(ctx, "flux", "reconcile", "source", "git", *name, "-n", *namespace)
-	} else {
-		cmd = exec.CommandContext(ctx, "flux", "reconcile", *kind, *name, "-n", *namespace)
-		if *kind == "kustomization" || *kind == "helmrelease" {
-			cmd.Args = append(cmd.Args, "--with-source")
+	if err := reconcileOne(ctx, dynamicClient, ref); err != nil {
+		output.PrintError(err.Error())
+		return 1
+	}
+
+	if wait && eventMonitor != nil {
+		output.PrintWaiting(ref.Kind, ref.Name)
+		if err := eventMonitor.WaitForReady(ctx, timeout); err != nil {
+			output.PrintError(fmt.Sprintf("Reconciliation failed or timed out: %v", err))
+			return 1
 		}
+		output.PrintSuccess(ref.Kind, ref.Name)
+	}
+	return 0
+}
+
+// runBatch reconciles multiple resources, honoring spec.dependsOn ordering
+// between them. Unlike the single-resource path, it always waits for each
+// level to become ready before starting the next, since that's what makes
+// the ordering meaningful.
+func runBatch(ctx context.Context, refs []events.ResourceRef, timeout time.Duration) int {
+	dynamicClient, err := events.NewDynamicClient()
+	if err != nil {
+		output.PrintError(err.Error())
+		return 1
 	}
 
-	// Run command and stream output
-	output.PrintCommand(cmd.Args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	results := events.RunBatch(ctx, dynamicClient, refs, timeout, func(ctx context.Context, ref events.ResourceRef) error {
+		return reconcileOne(ctx, dynamicClient, ref)
+	})
 
-	if err := cmd.Run(); err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			os.Exit(exitErr.ExitCode())
+	failures := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failures++
 		}
-		fmt.Fprintf(os.Stderr, "Error running flux: %v\n", err)
-		os.Exit(1)
 	}
+	if failures > 0 {
+		output.PrintError(fmt.Sprintf("%d of %d resources failed to reconcile", failures, len(results)))
+		return 1
+	}
+	return 0
+}
 
-	// Wait for reconciliation if requested
-	if *wait && eventMonitor != nil {
-		output.PrintWaiting(*kind, *name)
-		if err := eventMonitor.WaitForReady(ctx, *timeout); err != nil {
-			output.PrintError(fmt.Sprintf("Reconciliation failed or timed out: %v", err))
-			os.Exit(1)
-		}
-		output.PrintSuccess(*kind, *name)
+// reconcileOne triggers reconciliation of a single resource by patching its
+// reconcile.fluxcd.io/requestedAt annotation directly via the dynamic
+// client - exactly what the flux CLI itself does - so this tool has no
+// dependency on the flux binary being installed.
+func reconcileOne(ctx context.Context, dynamicClient dynamic.Interface, ref events.ResourceRef) error {
+	requestedAt, err := events.TriggerReconcile(ctx, dynamicClient, ref)
+	if err != nil {
+		return err
+	}
+	output.PrintCommand("patch", ref.String(), "reconcile.fluxcd.io/requestedAt="+requestedAt)
+	return nil
+}
+
+// runWithLeaderElection acquires leaseName in namespace before invoking run,
+// so that a Deployment of N replicas running this tool won't all trigger the
+// same reconciliation. It blocks until either this process becomes leader
+// and run returns, or ctx is cancelled.
+func runWithLeaderElection(ctx context.Context, leaseName, namespace string, run func(ctx context.Context) int) int {
+	clientset, err := events.NewClientset()
+	if err != nil {
+		output.PrintError(err.Error())
+		return 1
+	}
+
+	identity, err := os.Hostname()
+	if err != nil {
+		identity = fmt.Sprintf("flux-reconcile-cli-%d", os.Getpid())
+	}
+
+	code := 1
+	err = events.RunWithLeaderElection(ctx, clientset, leaseName, namespace, identity, func(ctx context.Context) {
+		code = run(ctx)
+	})
+	if err != nil {
+		output.PrintError(err.Error())
+		return 1
 	}
+	return code
 }
@@ -0,0 +1,218 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/junovy-hosting/flux-enhanced-cli/pkg/output"
+)
+
+// ResourceRef identifies a single Flux object to reconcile, either on its
+// own or as part of a Batch.
+type ResourceRef struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+func (r ResourceRef) String() string {
+	return fmt.Sprintf("%s/%s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// Result carries the outcome of reconciling a single resource in a Batch.
+type Result struct {
+	Ref ResourceRef
+	Err error
+}
+
+// ReconcileFunc triggers reconciliation for a single resource. It's supplied
+// by the caller so this package doesn't own how reconciliation is actually
+// triggered (shelling out to `flux`, patching an annotation, etc).
+type ReconcileFunc func(ctx context.Context, ref ResourceRef) error
+
+// dependencyKinds carry a spec.dependsOn field that gates their readiness on
+// other Flux objects.
+var dependencyKinds = map[string]bool{
+	"kustomization": true,
+	"helmrelease":   true,
+}
+
+// dependsOn fetches spec.dependsOn for a Kustomization or HelmRelease,
+// resolving each entry's namespace to the resource's own namespace when
+// left unspecified (matching Flux's own DependencyReference semantics).
+func dependsOn(ctx context.Context, dynamicClient dynamic.Interface, ref ResourceRef) ([]ResourceRef, error) {
+	gvr, ok := resourceGVRs[ref.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported resource kind: %s", ref.Kind)
+	}
+
+	obj, err := dynamicClient.Resource(gvr).Namespace(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+
+	raw, found, err := unstructured.NestedSlice(obj.Object, "spec", "dependsOn")
+	if !found || err != nil {
+		return nil, err
+	}
+
+	deps := make([]ResourceRef, 0, len(raw))
+	for _, dep := range raw {
+		depMap, ok := dep.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(depMap, "name")
+		namespace, _, _ := unstructured.NestedString(depMap, "namespace")
+		if namespace == "" {
+			namespace = ref.Namespace
+		}
+		deps = append(deps, ResourceRef{Kind: ref.Kind, Name: name, Namespace: namespace})
+	}
+	return deps, nil
+}
+
+// plan is a topologically sorted view of a set of resources: levels can each
+// reconcile in parallel, and deps records which of a resource's dependencies
+// are themselves part of this batch (dependencies outside the batch are
+// assumed already satisfied).
+type plan struct {
+	levels [][]ResourceRef
+	deps   map[ResourceRef][]ResourceRef
+}
+
+func buildPlan(ctx context.Context, dynamicClient dynamic.Interface, refs []ResourceRef) (*plan, error) {
+	inBatch := make(map[ResourceRef]bool, len(refs))
+	for _, ref := range refs {
+		inBatch[ref] = true
+	}
+
+	deps := make(map[ResourceRef][]ResourceRef, len(refs))
+	for _, ref := range refs {
+		if !dependencyKinds[ref.Kind] {
+			continue
+		}
+		all, err := dependsOn(ctx, dynamicClient, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve dependencies for %s: %w", ref, err)
+		}
+		for _, dep := range all {
+			if inBatch[dep] {
+				deps[ref] = append(deps[ref], dep)
+			}
+		}
+	}
+
+	var levels [][]ResourceRef
+	resolved := make(map[ResourceRef]bool, len(refs))
+	for len(resolved) < len(refs) {
+		var level []ResourceRef
+		for _, ref := range refs {
+			if resolved[ref] {
+				continue
+			}
+			ready := true
+			for _, dep := range deps[ref] {
+				if !resolved[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				level = append(level, ref)
+			}
+		}
+		if len(level) == 0 {
+			return nil, fmt.Errorf("circular dependsOn among remaining resources")
+		}
+		for _, ref := range level {
+			resolved[ref] = true
+		}
+		levels = append(levels, level)
+	}
+
+	return &plan{levels: levels, deps: deps}, nil
+}
+
+// RunBatch reconciles refs level by level, blocking each level until its
+// predecessors in the dependency graph reach Ready=True. Resources whose
+// dependencies failed or stalled are skipped rather than attempted.
+func RunBatch(ctx context.Context, dynamicClient dynamic.Interface, refs []ResourceRef, timeout time.Duration, reconcile ReconcileFunc) []Result {
+	p, err := buildPlan(ctx, dynamicClient, refs)
+	if err != nil {
+		return []Result{{Err: err}}
+	}
+
+	var (
+		mu      sync.Mutex
+		results []Result
+		failed  = make(map[ResourceRef]bool)
+	)
+
+	for _, level := range p.levels {
+		var wg sync.WaitGroup
+		for _, ref := range level {
+			ref := ref
+
+			var blockedBy ResourceRef
+			skip := false
+			for _, dep := range p.deps[ref] {
+				if failed[dep] {
+					skip, blockedBy = true, dep
+					break
+				}
+			}
+			if skip {
+				err := fmt.Errorf("skipped: dependency %s failed", blockedBy)
+				output.PrintError(fmt.Sprintf("%s: %v", ref, err))
+				mu.Lock()
+				results = append(results, Result{Ref: ref, Err: err})
+				failed[ref] = true
+				mu.Unlock()
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := reconcileOne(ctx, ref, timeout, reconcile)
+				mu.Lock()
+				results = append(results, Result{Ref: ref, Err: err})
+				if err != nil {
+					failed[ref] = true
+				}
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+	}
+
+	return results
+}
+
+func reconcileOne(ctx context.Context, ref ResourceRef, timeout time.Duration, reconcile ReconcileFunc) error {
+	monitor, err := NewMonitor(ctx, ref.Kind, ref.Name, ref.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to start monitor for %s: %w", ref, err)
+	}
+	defer monitor.Stop()
+	go monitor.Watch()
+
+	if err := reconcile(ctx, ref); err != nil {
+		return fmt.Errorf("failed to trigger reconcile for %s: %w", ref, err)
+	}
+
+	output.PrintWaiting(ref.Kind, ref.Name)
+	if err := monitor.WaitForReady(ctx, timeout); err != nil {
+		output.PrintError(fmt.Sprintf("%s: %v", ref, err))
+		return fmt.Errorf("%s: %w", ref, err)
+	}
+	output.PrintSuccess(ref.Kind, ref.Name)
+	return nil
+}
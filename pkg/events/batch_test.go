@@ -0,0 +1,162 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newFakeDynamicClient(objects ...*unstructured.Unstructured) dynamic.Interface {
+	scheme := runtime.NewScheme()
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		resourceGVRs["kustomization"]: "KustomizationList",
+		resourceGVRs["helmrelease"]:   "HelmReleaseList",
+	}
+
+	objs := make([]runtime.Object, len(objects))
+	for i, o := range objects {
+		objs[i] = o
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, gvrToListKind, objs...)
+}
+
+// kustomization builds an unstructured Kustomization with the given
+// spec.dependsOn entries, each as a {name: ...} or {name: ..., namespace: ...} map.
+func kustomization(name, namespace string, dependsOn ...map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+	}}
+
+	if len(dependsOn) == 0 {
+		return obj
+	}
+
+	deps := make([]interface{}, 0, len(dependsOn))
+	for _, d := range dependsOn {
+		dep := map[string]interface{}{"name": d["name"]}
+		if ns, ok := d["namespace"]; ok {
+			dep["namespace"] = ns
+		}
+		deps = append(deps, dep)
+	}
+	if err := unstructured.SetNestedSlice(obj.Object, deps, "spec", "dependsOn"); err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+func TestBuildPlanLinearChain(t *testing.T) {
+	a := kustomization("a", "flux-system")
+	b := kustomization("b", "flux-system", map[string]string{"name": "a"})
+	c := kustomization("c", "flux-system", map[string]string{"name": "b"})
+
+	client := newFakeDynamicClient(a, b, c)
+	refs := []ResourceRef{
+		{Kind: "kustomization", Name: "c", Namespace: "flux-system"},
+		{Kind: "kustomization", Name: "a", Namespace: "flux-system"},
+		{Kind: "kustomization", Name: "b", Namespace: "flux-system"},
+	}
+
+	p, err := buildPlan(context.Background(), client, refs)
+	if err != nil {
+		t.Fatalf("buildPlan: %v", err)
+	}
+	if len(p.levels) != 3 {
+		t.Fatalf("expected 3 levels, got %d: %+v", len(p.levels), p.levels)
+	}
+	if p.levels[0][0].Name != "a" || p.levels[1][0].Name != "b" || p.levels[2][0].Name != "c" {
+		t.Fatalf("unexpected level ordering: %+v", p.levels)
+	}
+}
+
+func TestBuildPlanParallelLevel(t *testing.T) {
+	a := kustomization("a", "flux-system")
+	b := kustomization("b", "flux-system")
+	c := kustomization("c", "flux-system", map[string]string{"name": "a"}, map[string]string{"name": "b"})
+
+	client := newFakeDynamicClient(a, b, c)
+	refs := []ResourceRef{
+		{Kind: "kustomization", Name: "a", Namespace: "flux-system"},
+		{Kind: "kustomization", Name: "b", Namespace: "flux-system"},
+		{Kind: "kustomization", Name: "c", Namespace: "flux-system"},
+	}
+
+	p, err := buildPlan(context.Background(), client, refs)
+	if err != nil {
+		t.Fatalf("buildPlan: %v", err)
+	}
+	if len(p.levels) != 2 {
+		t.Fatalf("expected 2 levels, got %d: %+v", len(p.levels), p.levels)
+	}
+	if len(p.levels[0]) != 2 {
+		t.Fatalf("expected first level to contain both independent resources, got %+v", p.levels[0])
+	}
+}
+
+func TestBuildPlanCycleDetected(t *testing.T) {
+	a := kustomization("a", "flux-system", map[string]string{"name": "b"})
+	b := kustomization("b", "flux-system", map[string]string{"name": "a"})
+
+	client := newFakeDynamicClient(a, b)
+	refs := []ResourceRef{
+		{Kind: "kustomization", Name: "a", Namespace: "flux-system"},
+		{Kind: "kustomization", Name: "b", Namespace: "flux-system"},
+	}
+
+	if _, err := buildPlan(context.Background(), client, refs); err == nil {
+		t.Fatal("expected circular dependsOn error, got nil")
+	}
+}
+
+func TestBuildPlanDependencyOutsideBatchIgnored(t *testing.T) {
+	// "a" depends on "external", which isn't part of this batch, so it
+	// should be treated as already satisfied rather than block forever.
+	a := kustomization("a", "flux-system", map[string]string{"name": "external"})
+
+	client := newFakeDynamicClient(a)
+	refs := []ResourceRef{{Kind: "kustomization", Name: "a", Namespace: "flux-system"}}
+
+	p, err := buildPlan(context.Background(), client, refs)
+	if err != nil {
+		t.Fatalf("buildPlan: %v", err)
+	}
+	if len(p.levels) != 1 || len(p.levels[0]) != 1 {
+		t.Fatalf("expected a single level with one resource, got %+v", p.levels)
+	}
+}
+
+func TestDependsOnDefaultsNamespace(t *testing.T) {
+	a := kustomization("a", "flux-system", map[string]string{"name": "b"})
+
+	client := newFakeDynamicClient(a)
+	deps, err := dependsOn(context.Background(), client, ResourceRef{Kind: "kustomization", Name: "a", Namespace: "flux-system"})
+	if err != nil {
+		t.Fatalf("dependsOn: %v", err)
+	}
+	if len(deps) != 1 || deps[0].Namespace != "flux-system" {
+		t.Fatalf("expected dependency namespace to default to flux-system, got %+v", deps)
+	}
+}
+
+func TestDependsOnRespectsExplicitNamespace(t *testing.T) {
+	a := kustomization("a", "flux-system", map[string]string{"name": "b", "namespace": "other-ns"})
+
+	client := newFakeDynamicClient(a)
+	deps, err := dependsOn(context.Background(), client, ResourceRef{Kind: "kustomization", Name: "a", Namespace: "flux-system"})
+	if err != nil {
+		t.Fatalf("dependsOn: %v", err)
+	}
+	if len(deps) != 1 || deps[0].Namespace != "other-ns" {
+		t.Fatalf("expected explicit dependency namespace to be preserved, got %+v", deps)
+	}
+}
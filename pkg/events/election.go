@@ -0,0 +1,47 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// RunWithLeaderElection acquires leaseName in namespace using the Lease API,
+// then calls run once this process becomes leader. Since this tool does a
+// single reconciliation pass rather than running forever, the election
+// context is cancelled as soon as run returns, releasing the lease.
+func RunWithLeaderElection(ctx context.Context, clientset *kubernetes.Clientset, leaseName, namespace, identity string, run func(ctx context.Context)) error {
+	electCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: namespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	leaderelection.RunOrDie(electCtx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				run(ctx)
+				cancel()
+			},
+			OnStoppedLeading: func() {},
+		},
+	})
+	return nil
+}
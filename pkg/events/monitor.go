@@ -6,19 +6,76 @@ import (
 	"sync"
 	"time"
 
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/junovy-hosting/flux-enhanced-cli/pkg/output"
 )
 
+// resourceKindNames maps every `-kind` this tool understands to the
+// Kubernetes `Kind` Flux stamps on `involvedObject.kind` for the events it
+// emits, so Watch can scope its field selector to the right object.
+var resourceKindNames = map[string]string{
+	"kustomization":         "Kustomization",
+	"helmrelease":           "HelmRelease",
+	"source":                "GitRepository",
+	"gitrepository":         "GitRepository",
+	"ocirepository":         "OCIRepository",
+	"bucket":                "Bucket",
+	"helmchart":             "HelmChart",
+	"alert":                 "Alert",
+	"provider":              "Provider",
+	"receiver":              "Receiver",
+	"imagerepository":       "ImageRepository",
+	"imagepolicy":           "ImagePolicy",
+	"imageupdateautomation": "ImageUpdateAutomation",
+}
+
+// resourceGVRs maps every `-kind` this tool understands to the GVR Flux
+// registers it under, so WaitForReady works uniformly across the whole
+// toolkit instead of special-casing a handful of resources.
+var resourceGVRs = map[string]schema.GroupVersionResource{
+	"kustomization":         {Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"},
+	"helmrelease":           {Group: "helm.toolkit.fluxcd.io", Version: "v2", Resource: "helmreleases"},
+	"source":                {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "gitrepositories"},
+	"gitrepository":         {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "gitrepositories"},
+	"ocirepository":         {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "ocirepositories"},
+	"bucket":                {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "buckets"},
+	"helmchart":             {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "helmcharts"},
+	"alert":                 {Group: "notification.toolkit.fluxcd.io", Version: "v1beta3", Resource: "alerts"},
+	"provider":              {Group: "notification.toolkit.fluxcd.io", Version: "v1beta3", Resource: "providers"},
+	"receiver":              {Group: "notification.toolkit.fluxcd.io", Version: "v1", Resource: "receivers"},
+	"imagerepository":       {Group: "image.toolkit.fluxcd.io", Version: "v1beta2", Resource: "imagerepositories"},
+	"imagepolicy":           {Group: "image.toolkit.fluxcd.io", Version: "v1beta2", Resource: "imagepolicies"},
+	"imageupdateautomation": {Group: "image.toolkit.fluxcd.io", Version: "v1beta1", Resource: "imageupdateautomations"},
+}
+
+// statuslessKinds have no `Ready` condition in their status (Flux never sets
+// one), so they're considered current as soon as they exist on the cluster.
+var statuslessKinds = map[string]bool{
+	"alert":    true,
+	"provider": true,
+	"receiver": true,
+}
+
+// IsMonitorableKind reports whether kind has a registered GVR and can be
+// passed to NewMonitor/WaitForReady.
+func IsMonitorableKind(kind string) bool {
+	_, ok := resourceGVRs[kind]
+	return ok
+}
+
 type Monitor struct {
 	kind          string
 	name          string
@@ -28,7 +85,7 @@ type Monitor struct {
 	ctx           context.Context
 	cancel        context.CancelFunc
 	mu            sync.Mutex
-	lastHash      string
+	seen          map[types.UID]int32
 	ready         bool
 	readyMu       sync.RWMutex
 }
@@ -59,10 +116,46 @@ func NewMonitor(ctx context.Context, kind, name, namespace string) (*Monitor, er
 		dynamicClient: dynamicClient,
 		ctx:           monitorCtx,
 		cancel:        cancel,
+		seen:          make(map[types.UID]int32),
 	}, nil
 }
 
+// NewDynamicClient builds a dynamic client using the same kubeconfig
+// resolution as NewMonitor, for callers (like batch reconciliation) that
+// need to inspect arbitrary Flux objects without monitoring a specific one.
+func NewDynamicClient() (dynamic.Interface, error) {
+	config, err := getKubeConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	return dynamic.NewForConfig(config)
+}
+
+// NewClientset builds a typed clientset using the same kubeconfig resolution
+// as NewMonitor, for callers (like leader election) that need core/v1 or
+// coordination/v1 APIs directly.
+func NewClientset() (*kubernetes.Clientset, error) {
+	config, err := getKubeConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// inCluster, when set via SetInCluster, restricts getKubeConfig to
+// in-cluster credentials instead of falling back to a local kubeconfig.
+var inCluster bool
+
+// SetInCluster forces getKubeConfig to use in-cluster credentials only. Use
+// this when running as a sidecar/Job, where a missing or stale local
+// kubeconfig should be a hard failure rather than a silent wrong cluster.
+func SetInCluster(v bool) { inCluster = v }
+
 func getKubeConfig() (*rest.Config, error) {
+	if inCluster {
+		return rest.InClusterConfig()
+	}
+
 	// Try in-cluster config first
 	config, err := rest.InClusterConfig()
 	if err == nil {
@@ -77,65 +170,87 @@ func getKubeConfig() (*rest.Config, error) {
 	return config, nil
 }
 
+// Watch streams events for the monitored resource via a SharedInformer
+// instead of polling, so new events (including repeats of the same reason,
+// e.g. HealthCheckFailed) show up as soon as the API server delivers them.
+// It blocks until the monitor's context is cancelled.
 func (m *Monitor) Watch() {
-	ticker := time.NewTicker(3 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-m.ctx.Done():
-			return
-		case <-ticker.C:
-			m.checkEvents()
-		}
-	}
-}
-
-func (m *Monitor) checkEvents() {
 	fieldSelector := fields.AndSelectors(
 		fields.OneTermEqualSelector("involvedObject.name", m.name),
 		fields.OneTermEqualSelector("involvedObject.namespace", m.namespace),
+		fields.OneTermEqualSelector("involvedObject.kind", resourceKindNames[m.kind]),
 	).String()
 
-	events, err := m.clientset.CoreV1().Events(m.namespace).List(m.ctx, metav1.ListOptions{
-		FieldSelector: fieldSelector,
-		Limit:         10,
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		m.clientset,
+		0,
+		informers.WithNamespace(m.namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fieldSelector
+		}),
+	)
+
+	informer := factory.Core().V1().Events().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    m.handleEvent,
+		UpdateFunc: func(_, newObj interface{}) { m.handleEvent(newObj) },
 	})
 
-	if err != nil {
+	factory.Start(m.ctx.Done())
+	factory.WaitForCacheSync(m.ctx.Done())
+	<-m.ctx.Done()
+}
+
+// handleEvent is the informer callback for both adds and updates. Flux
+// re-emits the same Event object with an incremented Count on repeats, so
+// deduping on UID+Count (rather than a message hash) shows every repeat
+// instead of collapsing them.
+func (m *Monitor) handleEvent(obj interface{}) {
+	evt, ok := obj.(*corev1.Event)
+	if !ok {
 		return
 	}
 
-	// Get the most recent events
-	if len(events.Items) == 0 {
+	m.mu.Lock()
+	if lastCount, seen := m.seen[evt.UID]; seen && lastCount >= evt.Count {
+		m.mu.Unlock()
 		return
 	}
+	m.seen[evt.UID] = evt.Count
+	m.mu.Unlock()
 
-	// Create a hash of recent events to detect changes
-	hash := ""
-	for i := len(events.Items) - 1; i >= 0 && i >= len(events.Items)-3; i-- {
-		evt := events.Items[i]
-		hash += fmt.Sprintf("%s:%s:%s", evt.Reason, evt.Type, evt.Message)
+	severity := output.SeverityInfo
+	if evt.Type == corev1.EventTypeWarning || evt.Reason == "HealthCheckFailed" || evt.Reason == "DependencyNotReady" {
+		severity = output.SeverityWarning
 	}
 
-	m.mu.Lock()
-	if hash != m.lastHash {
-		m.lastHash = hash
-		m.mu.Unlock()
+	output.PrintEvent(output.Event{
+		Kind:      m.kind,
+		Name:      m.name,
+		Namespace: m.namespace,
+		Reason:    evt.Reason,
+		Message:   fluxMessage(evt),
+		Revision:  fluxRevision(evt),
+		Severity:  severity,
+	})
+}
 
-		// Show the 2 most recent events
-		shown := 0
-		for i := len(events.Items) - 1; i >= 0 && shown < 2; i-- {
-			evt := events.Items[i]
-			isWarning := evt.Type == corev1.EventTypeWarning ||
-				evt.Reason == "HealthCheckFailed" ||
-				evt.Reason == "DependencyNotReady"
-			output.PrintEvent(evt.Reason, evt.Message, isWarning)
-			shown++
-		}
-	} else {
-		m.mu.Unlock()
+// fluxRevision pulls the git/OCI revision Flux stamps on its events out of
+// the event's annotations, so the user sees *which* commit was reconciled.
+func fluxRevision(evt *corev1.Event) string {
+	if rev := evt.Annotations["toolkit.fluxcd.io/revision"]; rev != "" {
+		return rev
+	}
+	return evt.Annotations["revision"]
+}
+
+// fluxMessage appends the source checksum, when present, to the event
+// message.
+func fluxMessage(evt *corev1.Event) string {
+	if checksum := evt.Annotations["checksum"]; checksum != "" {
+		return fmt.Sprintf("%s (checksum: %s)", evt.Message, checksum)
 	}
+	return evt.Message
 }
 
 func (m *Monitor) WaitForReady(ctx context.Context, timeout time.Duration) error {
@@ -143,46 +258,35 @@ func (m *Monitor) WaitForReady(ctx context.Context, timeout time.Duration) error
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 
-	// Determine the GVR for the resource
-	var gvr schema.GroupVersionResource
-	switch m.kind {
-	case "kustomization":
-		gvr = schema.GroupVersionResource{
-			Group:    "kustomize.toolkit.fluxcd.io",
-			Version:  "v1",
-			Resource: "kustomizations",
-		}
-	case "helmrelease":
-		gvr = schema.GroupVersionResource{
-			Group:    "helm.toolkit.fluxcd.io",
-			Version:  "v2beta1",
-			Resource: "helmreleases",
-		}
-	case "source", "gitrepository":
-		gvr = schema.GroupVersionResource{
-			Group:    "source.toolkit.fluxcd.io",
-			Version:  "v1",
-			Resource: "gitrepositories",
-		}
-	default:
+	gvr, ok := resourceGVRs[m.kind]
+	if !ok {
 		return fmt.Errorf("unsupported resource kind: %s", m.kind)
 	}
 
+	var lastMessage string
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
 			if time.Now().After(deadline) {
+				if lastMessage != "" {
+					return fmt.Errorf("timeout waiting for %s reconciliation: %s", m.kind, lastMessage)
+				}
 				return fmt.Errorf("timeout waiting for %s reconciliation", m.kind)
 			}
 
-			// Check if resource is ready using dynamic client
-			ready, err := m.checkResourceReady(gvr)
+			ready, failed, message, err := m.checkResourceReady(gvr)
 			if err != nil {
 				// Continue waiting if we can't check status
 				continue
 			}
+			if message != "" {
+				lastMessage = message
+			}
+			if failed {
+				return fmt.Errorf("%s/%s failed to reconcile: %s", m.kind, m.name, message)
+			}
 			if ready {
 				return nil
 			}
@@ -190,38 +294,36 @@ func (m *Monitor) WaitForReady(ctx context.Context, timeout time.Duration) error
 	}
 }
 
-func (m *Monitor) checkResourceReady(gvr schema.GroupVersionResource) (bool, error) {
+// checkResourceReady reports readiness via kstatus, which understands the
+// condition conventions used across the whole Flux toolkit instead of just
+// the bespoke `Ready` check this used to special-case. It returns (ready,
+// failed, message) so callers can distinguish "still reconciling" from a
+// terminal failure and surface the condition message in either case.
+func (m *Monitor) checkResourceReady(gvr schema.GroupVersionResource) (ready, failed bool, message string, err error) {
 	obj, err := m.dynamicClient.Resource(gvr).Namespace(m.namespace).Get(m.ctx, m.name, metav1.GetOptions{})
 	if err != nil {
-		return false, err
+		return false, false, "", err
 	}
 
-	// Check status.conditions for Ready condition
-	status, found, err := unstructured.NestedMap(obj.Object, "status")
-	if !found || err != nil {
-		return false, err
+	if statuslessKinds[m.kind] {
+		// No Ready condition will ever appear; existing is all we can ask for.
+		return true, false, "", nil
 	}
 
-	conditions, found, err := unstructured.NestedSlice(status, "conditions")
-	if !found || err != nil {
-		return false, err
+	result, err := status.Compute(obj)
+	if err != nil {
+		return false, false, "", err
 	}
 
-	for _, cond := range conditions {
-		condMap, ok := cond.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		condType, _, _ := unstructured.NestedString(condMap, "type")
-		condStatus, _, _ := unstructured.NestedString(condMap, "status")
-
-		if condType == "Ready" && condStatus == "True" {
-			return true, nil
-		}
+	switch result.Status {
+	case status.CurrentStatus:
+		return true, false, result.Message, nil
+	case status.FailedStatus, status.TerminatingStatus:
+		return false, true, result.Message, nil
+	default:
+		// InProgressStatus, NotFoundStatus, UnknownStatus: keep waiting.
+		return false, false, result.Message, nil
 	}
-
-	return false, nil
 }
 
 func (m *Monitor) Stop() {
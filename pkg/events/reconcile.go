@@ -0,0 +1,107 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// requestedAtAnnotation is the annotation `flux reconcile` itself patches to
+// trigger a reconciliation; Flux's controllers watch for it to change.
+const requestedAtAnnotation = "reconcile.fluxcd.io/requestedAt"
+
+// sourceKindGVRs maps the `Kind` used in a Kustomization/HelmRelease's
+// sourceRef to its GVR, so TriggerReconcile can resolve and patch it too.
+var sourceKindGVRs = map[string]schema.GroupVersionResource{
+	"GitRepository":  {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "gitrepositories"},
+	"OCIRepository":  {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "ocirepositories"},
+	"Bucket":         {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "buckets"},
+	"HelmChart":      {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "helmcharts"},
+	"HelmRepository": {Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "helmrepositories"},
+}
+
+// TriggerReconcile patches requestedAtAnnotation on ref with the current
+// time via the dynamic client, which is exactly what the `flux` CLI does
+// under the hood. This removes the dependency on the flux binary being
+// present, so the tool can run as a sidecar/Job. It returns the timestamp
+// that was patched, for logging.
+//
+// For Kustomizations and HelmReleases, it first resolves and patches the
+// object's sourceRef, mirroring `flux reconcile --with-source` so the
+// object doesn't reconcile against a stale source.
+func TriggerReconcile(ctx context.Context, dynamicClient dynamic.Interface, ref ResourceRef) (string, error) {
+	gvr, ok := resourceGVRs[ref.Kind]
+	if !ok {
+		return "", fmt.Errorf("unsupported resource kind: %s", ref.Kind)
+	}
+
+	if dependencyKinds[ref.Kind] {
+		if err := triggerSourceReconcile(ctx, dynamicClient, gvr, ref); err != nil {
+			return "", fmt.Errorf("failed to trigger source reconcile for %s: %w", ref, err)
+		}
+	}
+
+	requestedAt, err := patchRequestedAt(ctx, dynamicClient, gvr, ref.Namespace, ref.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to patch %s: %w", ref, err)
+	}
+	return requestedAt, nil
+}
+
+// triggerSourceReconcile resolves ref's sourceRef (spec.sourceRef for a
+// Kustomization, spec.chart.spec.sourceRef for a HelmRelease) and patches it
+// first, so the source is fresh by the time ref itself reconciles.
+func triggerSourceReconcile(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, ref ResourceRef) error {
+	obj, err := dynamicClient.Resource(gvr).Namespace(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	path := []string{"spec", "sourceRef"}
+	if ref.Kind == "helmrelease" {
+		path = []string{"spec", "chart", "spec", "sourceRef"}
+	}
+
+	sourceRef, found, err := unstructured.NestedMap(obj.Object, path...)
+	if !found || err != nil {
+		return err
+	}
+
+	kind, _, _ := unstructured.NestedString(sourceRef, "kind")
+	name, _, _ := unstructured.NestedString(sourceRef, "name")
+	namespace, _, _ := unstructured.NestedString(sourceRef, "namespace")
+	if namespace == "" {
+		namespace = ref.Namespace
+	}
+
+	sourceGVR, ok := sourceKindGVRs[kind]
+	if !ok {
+		return fmt.Errorf("unsupported sourceRef kind: %s", kind)
+	}
+
+	_, err = patchRequestedAt(ctx, dynamicClient, sourceGVR, namespace, name)
+	return err
+}
+
+// patchRequestedAt patches requestedAtAnnotation with the current time on
+// the named object and returns the value it patched.
+func patchRequestedAt(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, namespace, name string) (string, error) {
+	requestedAt := time.Now().Format(time.RFC3339Nano)
+	patch := []byte(fmt.Sprintf(
+		`{"metadata":{"annotations":{%q:%q}}}`, requestedAtAnnotation, requestedAt,
+	))
+
+	_, err := dynamicClient.Resource(gvr).Namespace(namespace).Patch(
+		ctx, name, types.MergePatchType, patch, metav1.PatchOptions{},
+	)
+	if err != nil {
+		return "", err
+	}
+	return requestedAt, nil
+}
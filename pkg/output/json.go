@@ -0,0 +1,97 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jsonSink implements Sink for both -output json and -output ndjson. In
+// ndjson mode each event is written (and flushed) as soon as it occurs, for
+// CI systems that tail the stream. In json mode events are buffered and
+// written on Close as one object holding the array plus summary counts, so `jq` can assert
+// against the whole run at once.
+type jsonSink struct {
+	w      io.Writer
+	ndjson bool
+
+	mu     sync.Mutex
+	events []Event
+}
+
+// NewJSONSink returns a Sink that writes Events as JSON to w. When ndjson is
+// true, each Event is written as its own line as it occurs; otherwise Events
+// are buffered and emitted as a single object on Close.
+func NewJSONSink(w io.Writer, ndjson bool) Sink {
+	return &jsonSink{w: w, ndjson: ndjson}
+}
+
+func (s *jsonSink) emit(e Event) {
+	e.Timestamp = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.ndjson {
+		s.events = append(s.events, e)
+		return
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	s.w.Write(append(data, '\n'))
+}
+
+func (s *jsonSink) Command(args []string) {
+	s.emit(Event{Phase: PhaseCommand, Message: strings.Join(args, " "), Severity: SeverityInfo})
+}
+
+func (s *jsonSink) Event(e Event) {
+	e.Phase = PhaseEvent
+	s.emit(e)
+}
+
+func (s *jsonSink) Waiting(kind, name string) {
+	s.emit(Event{Kind: kind, Name: name, Phase: PhaseWaiting, Severity: SeverityInfo})
+}
+
+func (s *jsonSink) Success(kind, name string) {
+	s.emit(Event{Kind: kind, Name: name, Phase: PhaseReady, Ready: true, Severity: SeverityInfo})
+}
+
+func (s *jsonSink) Error(message string) {
+	s.emit(Event{Phase: PhaseFailed, Message: message, Severity: SeverityError})
+}
+
+// summary is the single object written as the whole of `json` mode output:
+// every buffered event plus totals, so CI can check pass/fail without
+// scanning every event.
+type summary struct {
+	Events []Event `json:"events"`
+	Total  int     `json:"total"`
+	Failed int     `json:"failed"`
+}
+
+func (s *jsonSink) Close() error {
+	if s.ndjson {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := summary{Events: s.events, Total: len(s.events)}
+	for _, e := range s.events {
+		if e.Severity == SeverityError {
+			out.Failed++
+		}
+	}
+
+	enc := json.NewEncoder(s.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,155 @@
+// Package output renders reconciliation progress either for a human
+// (colored text on a terminal) or for a machine (JSON/NDJSON on stdout),
+// behind a common Sink interface so callers don't need to know which mode
+// is active.
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phase values used in Event.Phase.
+const (
+	PhaseCommand = "command"
+	PhaseEvent   = "event"
+	PhaseWaiting = "waiting"
+	PhaseReady   = "ready"
+	PhaseFailed  = "failed"
+)
+
+// Severity values used in Event.Severity.
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Event is a single reconciliation event or state transition, in the shape
+// CI consumers expect from -output json/ndjson.
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Kind      string    `json:"kind,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Phase     string    `json:"phase"`
+	Reason    string    `json:"reason,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Revision  string    `json:"revision,omitempty"`
+	Ready     bool      `json:"ready"`
+	Severity  string    `json:"severity"`
+}
+
+// Sink receives formatted reconciliation output. Text is the default,
+// human-facing sink; the JSON sinks are used in CI via -output.
+type Sink interface {
+	Command(args []string)
+	Event(e Event)
+	Waiting(kind, name string)
+	Success(kind, name string)
+	Error(message string)
+	Close() error
+}
+
+var (
+	mu      sync.Mutex
+	current Sink = NewTextSink(os.Stdout)
+)
+
+// SetSink installs the active sink. Call it once, right after parsing
+// -output, before anything else in this process emits output.
+func SetSink(s Sink) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = s
+}
+
+func active() Sink {
+	mu.Lock()
+	defer mu.Unlock()
+	return current
+}
+
+// PrintCommand reports an action taken against the cluster (e.g. the
+// requestedAt annotation patch that triggers a reconcile).
+func PrintCommand(args ...string) { active().Command(args) }
+
+// PrintEvent reports a Kubernetes event observed for a monitored resource.
+func PrintEvent(e Event) { active().Event(e) }
+
+// PrintWaiting reports that a resource's reconciliation is in flight.
+func PrintWaiting(kind, name string) { active().Waiting(kind, name) }
+
+// PrintSuccess reports that a resource reached Ready=True.
+func PrintSuccess(kind, name string) { active().Success(kind, name) }
+
+// PrintError reports a failure or timeout.
+func PrintError(message string) { active().Error(message) }
+
+// Close flushes and closes the active sink. The buffered `json` sink only
+// writes its array (and summary) here, so callers must invoke this before
+// the process exits.
+func Close() error { return active().Close() }
+
+const (
+	colorReset  = "\033[0m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorRed    = "\033[31m"
+	colorCyan   = "\033[36m"
+)
+
+// textSink is the default, human-facing Sink: short colorized lines.
+type textSink struct {
+	w io.Writer
+}
+
+// NewTextSink returns a Sink that writes colorized, human-readable lines to w.
+func NewTextSink(w io.Writer) Sink {
+	return &textSink{w: w}
+}
+
+func (s *textSink) Command(args []string) {
+	fmt.Fprintf(s.w, "%s$ %s%s\n", colorCyan, strings.Join(args, " "), colorReset)
+}
+
+func (s *textSink) Event(e Event) {
+	color := colorGreen
+	switch e.Severity {
+	case SeverityWarning:
+		color = colorYellow
+	case SeverityError:
+		color = colorRed
+	}
+
+	message := e.Message
+	if e.Revision != "" {
+		message = fmt.Sprintf("%s (revision: %s)", message, e.Revision)
+	}
+
+	reason := e.Reason
+	if e.Kind != "" && e.Name != "" {
+		// Prefix with the resource so concurrent batch reconciles (chunk0-3)
+		// don't interleave into an unattributable stream of [Reason] lines.
+		reason = fmt.Sprintf("%s/%s: %s", e.Kind, e.Name, reason)
+	}
+	fmt.Fprintf(s.w, "%s[%s] %s%s\n", color, reason, message, colorReset)
+}
+
+func (s *textSink) Waiting(kind, name string) {
+	fmt.Fprintf(s.w, "%sWaiting for %s/%s to become ready...%s\n", colorCyan, kind, name, colorReset)
+}
+
+func (s *textSink) Success(kind, name string) {
+	fmt.Fprintf(s.w, "%s✓ %s/%s is ready%s\n", colorGreen, kind, name, colorReset)
+}
+
+func (s *textSink) Error(message string) {
+	fmt.Fprintf(s.w, "%s✗ %s%s\n", colorRed, message, colorReset)
+}
+
+func (s *textSink) Close() error { return nil }